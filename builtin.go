@@ -0,0 +1,142 @@
+package dburl
+
+// init registers the schemes documented in the package overview, so that
+// Parse and Open work out of the box without requiring callers to call
+// Register themselves.
+func init() {
+	Register("mssql", Scheme{
+		Driver:    "mssql",
+		Aliases:   []string{"ms", "sqlserver"},
+		Generator: genMssql,
+	})
+	Register("mysql", Scheme{
+		Driver:    "mysql",
+		Aliases:   []string{"my", "mariadb", "maria", "percona", "aurora"},
+		Generator: genMysql,
+	})
+	Register("ora", Scheme{
+		Driver:    "ora",
+		Aliases:   []string{"or", "oracle", "oci8", "oci"},
+		Generator: genOracle,
+	})
+	Register("postgres", Scheme{
+		Driver:  "postgres",
+		Aliases: []string{"pg", "postgresql", "pgsql"},
+	})
+	Register("sqlite3", Scheme{
+		Driver:    "sqlite3",
+		Aliases:   []string{"sq", "sqlite", "file"},
+		Generator: genSQLite,
+		Opaque:    true,
+	})
+
+	Register("spanner", Scheme{
+		Driver:  "spanner",
+		Aliases: []string{"gs", "google", "span"},
+	})
+
+	Register("avatica", Scheme{
+		Driver:  "avatica",
+		Aliases: []string{"av", "phoenix"},
+	})
+	Register("clickhouse", Scheme{
+		Driver:  "clickhouse",
+		Aliases: []string{"ch"},
+	})
+	Register("cockroachdb", Scheme{
+		Driver:    "cockroachdb",
+		Aliases:   []string{"cr", "cockroach", "crdb", "cdb"},
+		Generator: GenScheme("postgres"),
+	})
+	Register("n1ql", Scheme{
+		Driver:  "n1ql",
+		Aliases: []string{"n1", "couchbase"},
+	})
+	Register("firebirdsql", Scheme{
+		Driver:  "firebirdsql",
+		Aliases: []string{"fb", "firebird"},
+	})
+	Register("memsql", Scheme{
+		Driver:    "memsql",
+		Aliases:   []string{"me"},
+		Generator: genMysql,
+	})
+	Register("adodb", Scheme{
+		Driver:    "adodb",
+		Aliases:   []string{"ad", "ado"},
+		Generator: genADODB,
+	})
+	Register("odbc", Scheme{
+		Driver:    "odbc",
+		Aliases:   []string{"od"},
+		Generator: genADODB,
+	})
+	Register("oleodbc", Scheme{
+		Driver:    "adodb",
+		Aliases:   []string{"oo", "ole"},
+		Generator: GenOLEODBC,
+	})
+	Register("ql", Scheme{
+		Driver: "ql",
+	})
+	Register("hdb", Scheme{
+		Driver:  "hdb",
+		Aliases: []string{"sa", "saphana", "sap", "hana"},
+	})
+	Register("sqlany", Scheme{
+		Driver:  "sqlany",
+		Aliases: []string{"sy", "sybase", "any"},
+	})
+	Register("voltdb", Scheme{
+		Driver:  "voltdb",
+		Aliases: []string{"vo", "volt", "vdb"},
+	})
+	Register("yql", Scheme{
+		Driver:  "yql",
+		Aliases: []string{"yq"},
+	})
+
+	// analytics/warehouse databases
+	Register("snowflake", Scheme{
+		Driver:    "snowflake",
+		Aliases:   []string{"sf"},
+		Generator: genSnowflake,
+	})
+	Register("bigquery", Scheme{
+		Driver:  "bigquery",
+		Aliases: []string{"bq"},
+	})
+	Register("redshift", Scheme{
+		Driver:    "postgres",
+		Aliases:   []string{"rs"},
+		Generator: genRedshift,
+	})
+	Register("presto", Scheme{
+		Driver:    "presto",
+		Aliases:   []string{"pr"},
+		Generator: genPresto,
+	})
+	Register("trino", Scheme{
+		Driver:    "trino",
+		Aliases:   []string{"tr"},
+		Generator: genPresto,
+	})
+	Register("cassandra", Scheme{
+		Driver:    "cassandra",
+		Aliases:   []string{"ca"},
+		Generator: genCassandra,
+	})
+	Register("hive", Scheme{
+		Driver:    "hive",
+		Aliases:   []string{"hi"},
+		Generator: genHive,
+	})
+	Register("vertica", Scheme{
+		Driver:  "vertica",
+		Aliases: []string{"ve"},
+	})
+	Register("impala", Scheme{
+		Driver:  "impala",
+		Aliases: []string{"im"},
+	})
+}
@@ -0,0 +1,113 @@
+package dburl
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// OpError wraps an error returned by OpenContext, Ping, or
+// OpenOptions.Open with the pipeline stage that produced it: "resolve"
+// for scheme resolution, "generate" for DSN generation, "open" for the
+// driver's sql.Open, or "ping" for the connection check.
+type OpError struct {
+	Op  string
+	Err error
+}
+
+// Error implements error.
+func (e *OpError) Error() string { return fmt.Sprintf("dburl: %s: %v", e.Op, e.Err) }
+
+// Unwrap returns the wrapped error, for use with errors.Is/errors.As.
+func (e *OpError) Unwrap() error { return e.Err }
+
+// OpenDB opens u using the driver and DSN it was resolved to by Parse.
+func OpenDB(u *URL) (*sql.DB, error) {
+	db, err := sql.Open(u.Driver, u.DSN)
+	if err != nil {
+		return nil, &OpError{"open", err}
+	}
+
+	return db, nil
+}
+
+// OpenContext parses urlstr, opens it with the resolved driver and DSN,
+// and verifies the connection with db.PingContext, so that a bad
+// connection string is caught immediately rather than on first use.
+//
+// A non-nil error is always an *OpError identifying which stage of the
+// pipeline failed.
+func OpenContext(ctx context.Context, urlstr string) (*sql.DB, error) {
+	u, stage, err := parseStaged(urlstr)
+	if err != nil {
+		return nil, &OpError{stage, err}
+	}
+
+	db, err := OpenDB(u)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, &OpError{"ping", err}
+	}
+
+	return db, nil
+}
+
+// Ping parses urlstr, opens it, and pings it, closing the connection
+// afterwards. It is a convenience for validating a connection string
+// without keeping the resulting *sql.DB around.
+func Ping(ctx context.Context, urlstr string) error {
+	db, err := OpenContext(ctx, urlstr)
+	if err != nil {
+		return err
+	}
+
+	return db.Close()
+}
+
+// MustOpen is like Open, but panics if urlstr cannot be parsed or opened.
+func MustOpen(urlstr string) *sql.DB {
+	db, err := Open(urlstr)
+	if err != nil {
+		panic(err)
+	}
+
+	return db
+}
+
+// OpenOptions configures pool settings applied to a *sql.DB by
+// OpenOptions.Open, mirroring the *sql.DB SetXXX methods of the same
+// name.
+type OpenOptions struct {
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	ConnMaxIdleTime time.Duration
+}
+
+// Open is OpenContext, additionally applying o to the returned *sql.DB.
+func (o OpenOptions) Open(ctx context.Context, urlstr string) (*sql.DB, error) {
+	db, err := OpenContext(ctx, urlstr)
+	if err != nil {
+		return nil, err
+	}
+
+	if o.MaxOpenConns != 0 {
+		db.SetMaxOpenConns(o.MaxOpenConns)
+	}
+	if o.MaxIdleConns != 0 {
+		db.SetMaxIdleConns(o.MaxIdleConns)
+	}
+	if o.ConnMaxLifetime != 0 {
+		db.SetConnMaxLifetime(o.ConnMaxLifetime)
+	}
+	if o.ConnMaxIdleTime != 0 {
+		db.SetConnMaxIdleTime(o.ConnMaxIdleTime)
+	}
+
+	return db, nil
+}
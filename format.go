@@ -0,0 +1,204 @@
+package dburl
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// Redacted returns the URL as a string, with any password replaced by
+// "xxxxx", matching the behavior of the standard library's
+// net/url.URL.Redacted.
+func (u *URL) Redacted() string {
+	z := u.URL
+	z.Scheme = u.Scheme
+	if z.User != nil {
+		if _, ok := z.User.Password(); ok {
+			z.User = url.UserPassword(z.User.Username(), "xxxxx")
+		}
+	}
+
+	return z.String()
+}
+
+// Short returns a compact "driver://user@host/dbname" form of the URL,
+// omitting the password and any query parameters, suitable for use in
+// prompts and log lines.
+func (u *URL) Short() string {
+	z := url.URL{
+		Scheme: u.Driver,
+		Host:   u.Host,
+		Path:   u.Path,
+	}
+	if u.User != nil {
+		z.User = url.User(u.User.Username())
+	}
+
+	return z.String()
+}
+
+// Format reconstructs a canonical dburl URL from dsn, a raw driver DSN as
+// accepted by driver's sql.Open, for one of the built-in schemes whose
+// driver is named driver. It is the inverse of the DSN Generator that
+// Parse would have used to produce dsn in the first place.
+//
+// Format only supports built-in schemes whose DSN unambiguously encodes a
+// URL; for others, it returns ErrInvalidDSN.
+func Format(driver, dsn string) (*URL, error) {
+	canon, _, ok := lookupScheme(driver)
+	if !ok {
+		return nil, ErrUnknownDatabaseScheme
+	}
+
+	var urlstr string
+	var err error
+	switch canon {
+	case "mysql", "memsql":
+		urlstr, err = formatMysqlDSN(canon, dsn)
+	case "ora":
+		urlstr, err = formatOracleDSN(canon, dsn)
+	case "snowflake", "hive":
+		urlstr, err = formatUserHostPathDSN(canon, dsn)
+	case "sqlite3":
+		urlstr = canon + ":" + dsn
+	case "mssql":
+		urlstr, err = formatMssqlDSN(dsn)
+	case "cassandra":
+		// fold the "keyspace" query parameter built by genCassandra back
+		// into the path.
+		host, query := dsn, ""
+		if i := strings.Index(dsn, "?"); i > -1 {
+			host, query = dsn[:i], dsn[i+1:]
+		}
+		q, perr := url.ParseQuery(query)
+		if perr != nil || host == "" {
+			return nil, ErrInvalidDSN
+		}
+		urlstr = canon + "://" + host + "/" + q.Get("keyspace")
+		if q.Del("keyspace"); len(q) != 0 {
+			urlstr += "?" + q.Encode()
+		}
+	default:
+		urlstr, err = formatURLDSN(canon, dsn)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return Parse(urlstr)
+}
+
+// formatURLDSN reconstructs a URL for a Scheme whose DSN is itself a URL
+// (the default Generator, or one built with GenScheme), by reparsing dsn
+// and rewriting its scheme to canon.
+func formatURLDSN(canon, dsn string) (string, error) {
+	v, err := url.Parse(dsn)
+	if err != nil || (v.Host == "" && v.Opaque == "") {
+		return "", ErrInvalidDSN
+	}
+	v.Scheme = canon
+
+	return v.String(), nil
+}
+
+// mysqlDSNRE matches a go-sql-driver/mysql style DSN, as built by
+// genMysql.
+var mysqlDSNRE = regexp.MustCompile(`^(?:([^:@]*)(?::([^@]*))?@)?(\w+)\(([^)]*)\)/([^?]*)(?:\?(.*))?$`)
+
+// formatMysqlDSN reconstructs a URL from a mysql-style DSN.
+func formatMysqlDSN(canon, dsn string) (string, error) {
+	m := mysqlDSNRE.FindStringSubmatch(dsn)
+	if m == nil {
+		return "", ErrInvalidDSN
+	}
+	user, pass, transport, addr, dbname, query := m[1], m[2], m[3], m[4], m[5], m[6]
+
+	if transport == "unix" {
+		urlstr := canon + ":" + addr
+		if query != "" {
+			urlstr += "?" + query
+		}
+		return urlstr, nil
+	}
+
+	urlstr := canon + "://" + credPrefix(user, pass) + addr + "/" + dbname
+	if query != "" {
+		urlstr += "?" + query
+	}
+
+	return urlstr, nil
+}
+
+// formatMssqlDSN reconstructs a URL from a "sqlserver://" DSN, folding
+// the "database" query parameter built by genMssql back into the
+// "/instance/dbname" path form.
+func formatMssqlDSN(dsn string) (string, error) {
+	v, err := url.Parse(dsn)
+	if err != nil {
+		return "", ErrInvalidDSN
+	}
+
+	var segs []string
+	if inst := strings.TrimPrefix(v.Path, "/"); inst != "" {
+		segs = append(segs, inst)
+	}
+	q := v.Query()
+	if db := q.Get("database"); db != "" {
+		segs = append(segs, db)
+		q.Del("database")
+	}
+
+	v.Scheme = "mssql"
+	v.Path = "/" + strings.Join(segs, "/")
+	v.RawQuery = q.Encode()
+
+	return v.String(), nil
+}
+
+// userHostPathRE matches the "user:pass@host/path?query" DSN form built
+// by dsnUserHostPath.
+var userHostPathRE = regexp.MustCompile(`^(?:([^:@]*)(?::([^@]*))?@)?([^/?]*)(/[^?]*)?(?:\?(.*))?$`)
+
+// formatUserHostPathDSN reconstructs a URL from a DSN built by
+// dsnUserHostPath.
+func formatUserHostPathDSN(canon, dsn string) (string, error) {
+	m := userHostPathRE.FindStringSubmatch(dsn)
+	if m == nil {
+		return "", ErrInvalidDSN
+	}
+	user, pass, host, path, query := m[1], m[2], m[3], m[4], m[5]
+
+	urlstr := canon + "://" + credPrefix(user, pass) + host + path
+	if query != "" {
+		urlstr += "?" + query
+	}
+
+	return urlstr, nil
+}
+
+// oracleDSNRE matches the "user/pass@host/sid" DSN form built by
+// genOracle.
+var oracleDSNRE = regexp.MustCompile(`^(?:([^/@]*)(?:/([^@]*))?@)?(.*)$`)
+
+// formatOracleDSN reconstructs a URL from a DSN built by genOracle.
+func formatOracleDSN(canon, dsn string) (string, error) {
+	m := oracleDSNRE.FindStringSubmatch(dsn)
+	if m == nil {
+		return "", ErrInvalidDSN
+	}
+
+	return canon + "://" + credPrefix(m[1], m[2]) + m[3], nil
+}
+
+// credPrefix renders "user:pass@", "user@", or "" from a username and
+// password, for use when reassembling a URL string.
+func credPrefix(user, pass string) string {
+	if user == "" {
+		return ""
+	}
+	if pass != "" {
+		return user + ":" + pass + "@"
+	}
+
+	return user + "@"
+}
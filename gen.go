@@ -0,0 +1,243 @@
+package dburl
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// GenFromURL is the default DSN generator, used by Schemes that do not
+// specify their own Generator. It returns u re-rendered with Driver in
+// place of the original scheme, leaving the rest of the URL untouched.
+func GenFromURL(u *URL) (string, error) {
+	z := u.URL
+	z.Scheme = u.Driver
+	return z.String(), nil
+}
+
+// GenScheme returns a DSN generator that rewrites a URL's scheme to
+// scheme and renders the result as a standard URL string. It is for
+// drivers whose DSN is itself a URL under a different scheme name, such
+// as CockroachDB's use of the PostgreSQL wire protocol.
+func GenScheme(scheme string) GenFunc {
+	return func(u *URL) (string, error) {
+		z := u.URL
+		z.Scheme = scheme
+		return z.String(), nil
+	}
+}
+
+// genMysql builds a go-sql-driver/mysql DSN of the form
+// "user:pass@transport(host:port)/dbname?params", using "unix" as the
+// transport when the URL has no host, eg "mysql:/var/run/mysqld/mysqld.sock".
+func genMysql(u *URL) (string, error) {
+	var cred string
+	if u.User != nil {
+		cred = u.User.Username()
+		if pw, ok := u.User.Password(); ok {
+			cred += ":" + pw
+		}
+		cred += "@"
+	}
+
+	transport, addr, dbname := u.Transport, u.Host, strings.TrimPrefix(u.Path, "/")
+	if addr == "" {
+		transport, addr, dbname = "unix", u.Path, ""
+	} else if transport == "" {
+		transport = "tcp"
+	}
+
+	dsn := cred + transport + "(" + addr + ")/" + dbname
+	if u.RawQuery != "" {
+		dsn += "?" + u.RawQuery
+	}
+
+	return dsn, nil
+}
+
+// genOracle builds a "user/pass@host/sid" DSN as expected by the Oracle
+// drivers.
+func genOracle(u *URL) (string, error) {
+	var cred string
+	if u.User != nil {
+		cred = u.User.Username()
+		if pw, ok := u.User.Password(); ok {
+			cred += "/" + pw
+		}
+		cred += "@"
+	}
+
+	return cred + u.Host + u.Path, nil
+}
+
+// genSQLite builds a sqlite3 DSN, which is simply the file path plus any
+// driver query parameters.
+func genSQLite(u *URL) (string, error) {
+	path := u.Host + u.Path
+	if u.RawQuery != "" {
+		path += "?" + u.RawQuery
+	}
+
+	return path, nil
+}
+
+// genMssql builds a "sqlserver://" DSN, folding the "/instance/dbname" or
+// "/dbname" path form used by mssql URLs into the "database" query
+// parameter expected by go-mssqldb.
+func genMssql(u *URL) (string, error) {
+	z := u.URL
+	z.Scheme = "sqlserver"
+
+	q := z.Query()
+	switch parts := strings.Split(strings.TrimPrefix(u.Path, "/"), "/"); len(parts) {
+	case 2:
+		z.Path = "/" + parts[0]
+		q.Set("database", parts[1])
+	case 1:
+		if parts[0] != "" {
+			q.Set("database", parts[0])
+		}
+		z.Path = ""
+	}
+	z.RawQuery = q.Encode()
+
+	return z.String(), nil
+}
+
+// genADODB builds a semicolon-delimited ADODB/ODBC connection string of
+// the form "key1=value1;key2=value2;...", merging the URL's user info,
+// host, and path into the driver query parameters, with keys sorted for
+// deterministic output.
+func genADODB(u *URL) (string, error) {
+	q := url.Values{}
+	for k, v := range u.Query() {
+		q[k] = v
+	}
+	if u.User != nil {
+		if un := u.User.Username(); un != "" {
+			q.Set("User Id", un)
+		}
+		if pw, ok := u.User.Password(); ok {
+			q.Set("Password", pw)
+		}
+	}
+	if u.Host != "" {
+		q.Set("Data Source", u.Host)
+	}
+	if db := strings.TrimPrefix(u.Path, "/"); db != "" {
+		q.Set("Database", db)
+	}
+	q.Set("Provider", u.Unaliased)
+
+	keys := make([]string, 0, len(q))
+	for k := range q {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, len(keys))
+	for i, k := range keys {
+		parts[i] = k + "=" + q.Get(k)
+	}
+
+	return strings.Join(parts, ";") + ";", nil
+}
+
+// GenOLEODBC generates an ADODB DSN that uses the "MSDASQL.1" OLE
+// provider, with the URL's user info, host, path, and query encoded into
+// the underlying ODBC connection's "Extended Properties" as built by
+// genADODB. The transport of an "oleodbc+protocol://" URL is not included
+// in the Extended Properties.
+func GenOLEODBC(u *URL) (string, error) {
+	props, err := genADODB(u)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("Provider=MSDASQL.1;Extended Properties=%q;", props), nil
+}
+
+// dsnUserHostPath renders "user:pass@host/path?query" from u, the DSN form
+// shared by several of the drivers below.
+func dsnUserHostPath(u *URL) string {
+	var cred string
+	if u.User != nil {
+		cred = u.User.Username()
+		if pw, ok := u.User.Password(); ok {
+			cred += ":" + pw
+		}
+		cred += "@"
+	}
+
+	dsn := cred + u.Host + u.Path
+	if u.RawQuery != "" {
+		dsn += "?" + u.RawQuery
+	}
+
+	return dsn
+}
+
+// genSnowflake builds a Snowflake DSN of the form
+// "user:pass@account/db/schema?warehouse=...&role=...".
+func genSnowflake(u *URL) (string, error) {
+	return dsnUserHostPath(u), nil
+}
+
+// genHive builds a Hive DSN of the form "user:pass@host:port/db?params".
+func genHive(u *URL) (string, error) {
+	return dsnUserHostPath(u), nil
+}
+
+// genRedshift builds a Redshift DSN by treating the URL as a postgres
+// connection string, defaulting sslmode to "require" when not specified.
+func genRedshift(u *URL) (string, error) {
+	z := u.URL
+	z.Scheme = "postgres"
+
+	q := z.Query()
+	if q.Get("sslmode") == "" {
+		q.Set("sslmode", "require")
+	}
+	z.RawQuery = q.Encode()
+
+	return z.String(), nil
+}
+
+// genPresto builds an HTTP-based Presto/Trino DSN, folding the
+// "/catalog/schema" or "/catalog" path form into the "catalog" and
+// "schema" query parameters expected by the client drivers.
+func genPresto(u *URL) (string, error) {
+	z := u.URL
+	z.Scheme = "http"
+
+	q := z.Query()
+	switch parts := strings.Split(strings.TrimPrefix(u.Path, "/"), "/"); len(parts) {
+	case 2:
+		q.Set("catalog", parts[0])
+		q.Set("schema", parts[1])
+	case 1:
+		if parts[0] != "" {
+			q.Set("catalog", parts[0])
+		}
+	}
+	z.Path = ""
+	z.RawQuery = q.Encode()
+
+	return z.String(), nil
+}
+
+// genCassandra builds a Cassandra/ScyllaDB DSN of the form
+// "host:port?keyspace=name&...", folding the URL's path into the
+// "keyspace" query parameter.
+func genCassandra(u *URL) (string, error) {
+	q := u.Query()
+	if ks := strings.TrimPrefix(u.Path, "/"); ks != "" {
+		q.Set("keyspace", ks)
+	}
+	if len(q) == 0 {
+		return u.Host, nil
+	}
+
+	return u.Host + "?" + q.Encode(), nil
+}
@@ -0,0 +1,128 @@
+package dburl
+
+import (
+	"bufio"
+	"os"
+	"strings"
+)
+
+// lookupPasswordFile resolves the password for u from the password file at
+// path.
+func lookupPasswordFile(path string, u *URL) (string, bool) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", false
+	}
+	defer f.Close()
+
+	if strings.HasSuffix(path, ".cnf") {
+		return lookupMyCnf(f, u)
+	}
+
+	return lookupPgpass(f, u)
+}
+
+// lookupPgpass scans a ".pgpass"-style file, matching lines of the form
+// "hostname:port:database:username:password" against u, where a field of
+// "*" matches anything. The first matching line wins.
+func lookupPgpass(f *os.File, u *URL) (string, bool) {
+	host, port := u.Hostname(), u.Port()
+	if port == "" {
+		port = "5432"
+	}
+	db := strings.TrimPrefix(u.Path, "/")
+	var user string
+	if u.User != nil {
+		user = u.User.Username()
+	}
+
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		line := sc.Text()
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := splitPgpassLine(line)
+		if len(fields) != 5 {
+			continue
+		}
+		if pgpassMatch(fields[0], host) && pgpassMatch(fields[1], port) &&
+			pgpassMatch(fields[2], db) && pgpassMatch(fields[3], user) {
+			return fields[4], true
+		}
+	}
+
+	return "", false
+}
+
+// pgpassMatch reports whether a pgpass field matches value, where "*"
+// matches anything.
+func pgpassMatch(field, value string) bool {
+	return field == "*" || field == value
+}
+
+// splitPgpassLine splits a pgpass entry on unescaped colons, per the
+// escaping rules documented for the format: "\:" is a literal colon and
+// "\\" is a literal backslash.
+func splitPgpassLine(line string) []string {
+	var fields []string
+	var cur strings.Builder
+	esc := false
+	for _, r := range line {
+		switch {
+		case esc:
+			cur.WriteRune(r)
+			esc = false
+		case r == '\\':
+			esc = true
+		case r == ':':
+			fields = append(fields, cur.String())
+			cur.Reset()
+		default:
+			cur.WriteRune(r)
+		}
+	}
+	fields = append(fields, cur.String())
+
+	return fields
+}
+
+// lookupMyCnf scans a ".my.cnf"-style ini file for a "user"/"password"
+// pair under the "[client]" section, returning the password if present
+// and, when u already has a username, only if it matches.
+func lookupMyCnf(f *os.File, u *URL) (string, bool) {
+	var inClient bool
+	var user, pass string
+
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		switch {
+		case line == "" || strings.HasPrefix(line, "#"):
+			continue
+		case strings.HasPrefix(line, "["):
+			inClient = line == "[client]"
+		case inClient:
+			parts := strings.SplitN(line, "=", 2)
+			if len(parts) != 2 {
+				continue
+			}
+			switch key, val := strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1]); key {
+			case "user":
+				user = val
+			case "password":
+				pass = val
+			}
+		}
+	}
+
+	if pass == "" {
+		return "", false
+	}
+	if u.User != nil && user != "" && user != u.User.Username() {
+		return "", false
+	}
+
+	return pass, true
+}
@@ -0,0 +1,113 @@
+package dburl
+
+import "sync"
+
+// GenFunc is a function that builds a driver-specific DSN from a parsed
+// URL. It is used by Scheme's Generator field.
+type GenFunc func(*URL) (string, error)
+
+// Scheme describes a registered protocol scheme: its canonical driver
+// name, aliases, DSN generator, and URL parsing behavior.
+type Scheme struct {
+	// Driver is the canonical SQL driver name, as passed to sql.Open.
+	Driver string
+
+	// Aliases are the additional scheme names that resolve to this
+	// Scheme when registered.
+	Aliases []string
+
+	// Generator builds the driver DSN from a parsed URL. When nil,
+	// GenFromURL is used.
+	Generator GenFunc
+
+	// Opaque indicates that the scheme is conventionally written as
+	// "protocol:path" (no "//" authority), eg sqlite or file. It is
+	// informational only -- Parse accepts either form regardless.
+	Opaque bool
+
+	// Transport, when non-empty, is the only "transport" value accepted
+	// in "protocol+transport://..." URLs for this Scheme.
+	Transport string
+}
+
+var (
+	// regMu guards schemeMap and aliasMap, making Register, RegisterAlias,
+	// Unregister, and lookupScheme safe for concurrent use.
+	regMu sync.RWMutex
+
+	// schemeMap holds registered Schemes, keyed by their canonical name.
+	schemeMap = make(map[string]Scheme)
+
+	// aliasMap maps an alias to the canonical name of the Scheme it
+	// resolves to.
+	aliasMap = make(map[string]string)
+)
+
+// Register adds s to the set of recognized schemes under name, and
+// registers any of s.Aliases alongside it. A later call with the same
+// name replaces the previous registration.
+//
+// Register is intended for downstream tools and code generators that need
+// to support database drivers dburl does not know about out of the box.
+func Register(name string, s Scheme) {
+	regMu.Lock()
+	defer regMu.Unlock()
+
+	schemeMap[name] = s
+	for _, a := range s.Aliases {
+		aliasMap[a] = name
+	}
+}
+
+// RegisterAlias registers alias as an additional name for the Scheme
+// already registered under scheme. It returns ErrUnknownDatabaseScheme if
+// scheme has not been registered.
+func RegisterAlias(alias, scheme string) error {
+	regMu.Lock()
+	defer regMu.Unlock()
+
+	if _, ok := schemeMap[scheme]; !ok {
+		return ErrUnknownDatabaseScheme
+	}
+	aliasMap[alias] = scheme
+
+	return nil
+}
+
+// Unregister removes the Scheme registered under name, along with any of
+// its aliases, and returns the removed Scheme. The zero Scheme and false
+// are returned if name was not registered.
+func Unregister(name string) (Scheme, bool) {
+	regMu.Lock()
+	defer regMu.Unlock()
+
+	s, ok := schemeMap[name]
+	if !ok {
+		return Scheme{}, false
+	}
+	delete(schemeMap, name)
+	for a, canon := range aliasMap {
+		if canon == name {
+			delete(aliasMap, a)
+		}
+	}
+
+	return s, true
+}
+
+// lookupScheme resolves name (a scheme or alias) to its canonical name and
+// registered Scheme.
+func lookupScheme(name string) (string, Scheme, bool) {
+	regMu.RLock()
+	defer regMu.RUnlock()
+
+	if s, ok := schemeMap[name]; ok {
+		return name, s, true
+	}
+	if canon, ok := aliasMap[name]; ok {
+		s, ok := schemeMap[canon]
+		return canon, s, ok
+	}
+
+	return "", Scheme{}, false
+}
@@ -0,0 +1,62 @@
+package dburl
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestRegisterUnregister(t *testing.T) {
+	Register("testscheme", Scheme{
+		Driver:  "testdriver",
+		Aliases: []string{"ts"},
+	})
+	defer Unregister("testscheme")
+
+	u, err := Parse("ts://localhost/db")
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+	if u.Driver != "testdriver" {
+		t.Errorf("Driver = %q, want %q", u.Driver, "testdriver")
+	}
+	if u.Unaliased != "testscheme" {
+		t.Errorf("Unaliased = %q, want %q", u.Unaliased, "testscheme")
+	}
+
+	if _, ok := Unregister("testscheme"); !ok {
+		t.Error("Unregister returned false for a registered scheme")
+	}
+	if _, err := Parse("ts://localhost/db"); err != ErrUnknownDatabaseScheme {
+		t.Errorf("Parse after Unregister returned %v, want ErrUnknownDatabaseScheme", err)
+	}
+}
+
+func TestRegisterAlias(t *testing.T) {
+	Register("testscheme2", Scheme{Driver: "testdriver2"})
+	defer Unregister("testscheme2")
+
+	if err := RegisterAlias("ts2", "testscheme2"); err != nil {
+		t.Fatalf("RegisterAlias returned error: %v", err)
+	}
+	if _, err := Parse("ts2://localhost/db"); err != nil {
+		t.Errorf("Parse returned error: %v", err)
+	}
+
+	if err := RegisterAlias("ts3", "nosuchscheme"); err != ErrUnknownDatabaseScheme {
+		t.Errorf("RegisterAlias for an unknown scheme returned %v, want ErrUnknownDatabaseScheme", err)
+	}
+}
+
+func TestRegisterConcurrent(t *testing.T) {
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			Register("concurrent", Scheme{Driver: "concurrentdriver"})
+			lookupScheme("concurrent")
+		}(i)
+	}
+	wg.Wait()
+	Unregister("concurrent")
+}
@@ -0,0 +1,110 @@
+package dburl
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// URL wraps the standard net/url.URL, adding the fields Parse resolves
+// from the registered Scheme: the actual driver to use with sql.Open, and
+// the driver-specific DSN built by that Scheme's Generator.
+type URL struct {
+	url.URL
+
+	// Scheme is the original protocol as it appeared in the parsed URL
+	// string, including any "+transport" suffix.
+	Scheme string
+
+	// Driver is the canonical SQL driver name, suitable for sql.Open.
+	Driver string
+
+	// Unaliased is the canonical scheme name that Driver was resolved
+	// from, with any "+transport" suffix removed.
+	Unaliased string
+
+	// Transport is the transport protocol, as specified in
+	// "protocol+transport://..." URLs, or the Scheme's default.
+	Transport string
+
+	// DSN is the driver-specific data source name built from the URL by
+	// the registered Scheme's Generator.
+	DSN string
+}
+
+// Parse parses urlstr, resolving its scheme against the registered
+// Schemes and building a driver DSN, returning the result as a URL.
+//
+// See the package documentation for the accepted URL formats, and
+// Register for adding additional schemes.
+func Parse(urlstr string) (*URL, error) {
+	u, _, err := parseStaged(urlstr)
+	return u, err
+}
+
+// parseStaged is Parse, additionally reporting which stage of parsing
+// produced an error -- "resolve" for scheme lookup and URL parsing, or
+// "generate" for DSN generation -- so that OpenContext and Ping can
+// attribute a failure precisely.
+func parseStaged(urlstr string) (*URL, string, error) {
+	i := strings.Index(urlstr, ":")
+	if i < 1 {
+		return nil, "resolve", ErrInvalidDatabaseScheme
+	}
+	proto, rest := urlstr[:i], urlstr[i:]
+
+	scheme, transport := proto, ""
+	if j := strings.Index(proto, "+"); j > -1 {
+		scheme, transport = proto[:j], proto[j+1:]
+	}
+
+	unaliased, s, ok := lookupScheme(scheme)
+	if !ok {
+		return nil, "resolve", ErrUnknownDatabaseScheme
+	}
+	if transport == "" {
+		transport = s.Transport
+	} else if s.Transport != "" && transport != s.Transport {
+		return nil, "resolve", ErrInvalidTransportProtocol
+	}
+
+	// reassemble with the canonical driver name so net/url's normal
+	// authority/opaque parsing rules apply.
+	v, err := url.Parse(s.Driver + rest)
+	if err != nil {
+		return nil, "resolve", err
+	}
+
+	// normalize "driver:path" (no "//" authority) to a plain path, so
+	// opaque and authority forms can be handled the same way downstream,
+	// eg "mysql:/var/run/mysqld/mysqld.sock" and "sqlite:/path/to/file.db".
+	if v.Opaque != "" {
+		v.Path, v.Opaque = v.Opaque, ""
+	}
+
+	if port := v.Port(); port != "" {
+		if _, err := strconv.Atoi(port); err != nil {
+			return nil, "resolve", ErrInvalidPort
+		}
+	}
+
+	u := &URL{
+		URL:       *v,
+		Scheme:    proto,
+		Driver:    s.Driver,
+		Unaliased: unaliased,
+		Transport: transport,
+	}
+
+	gen := s.Generator
+	if gen == nil {
+		gen = GenFromURL
+	}
+	dsn, err := gen(u)
+	if err != nil {
+		return nil, "generate", err
+	}
+	u.DSN = dsn
+
+	return u, "", nil
+}